@@ -0,0 +1,17 @@
+// Package chiSwagger wraps httpSwagger.Handler for use with chi routes such
+// as r.Get("/swagger/*", chiSwagger.WrapHandler()).
+package chiSwagger
+
+import (
+	"net/http"
+
+	httpSwagger "github.com/oilastudio/http-swagger"
+)
+
+// WrapHandler wraps httpSwagger.Handler and returns an http.HandlerFunc. chi
+// routers operate on the standard net/http types already, so no adaptation
+// of the handler's URL-matching regex is needed beyond the wildcard route
+// pattern chi itself requires (e.g. "/swagger/*").
+func WrapHandler(configFns ...func(*httpSwagger.Config)) http.HandlerFunc {
+	return httpSwagger.Handler(configFns...)
+}
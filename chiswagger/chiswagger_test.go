@@ -0,0 +1,39 @@
+package chiSwagger_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	chiSwagger "github.com/oilastudio/http-swagger/chiswagger"
+)
+
+func TestWrapHandler_MountedUnderPrefix(t *testing.T) {
+	router := chi.NewRouter()
+	router.Get("/api/v1/swagger/*", chiSwagger.WrapHandler())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/swagger/index.html", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+}
+
+func TestWrapHandler_RedirectsRoot(t *testing.T) {
+	router := chi.NewRouter()
+	router.Get("/swagger/*", chiSwagger.WrapHandler())
+
+	req := httptest.NewRequest(http.MethodGet, "/swagger/", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusMovedPermanently {
+		t.Fatalf("expected status 301, got %d", w.Code)
+	}
+}
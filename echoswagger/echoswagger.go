@@ -0,0 +1,26 @@
+// Package echoSwagger wraps httpSwagger.Handler for use with echo routes
+// such as e.GET("/swagger/*", echoSwagger.WrapHandler()).
+package echoSwagger
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	httpSwagger "github.com/oilastudio/http-swagger"
+)
+
+// WrapHandler wraps httpSwagger.Handler and returns an echo.HandlerFunc.
+func WrapHandler(configFns ...func(*httpSwagger.Config)) echo.HandlerFunc {
+	return CustomWrapHandler(httpSwagger.Handler(configFns...))
+}
+
+// CustomWrapHandler adapts an already-built httpSwagger.Handler into an
+// echo.HandlerFunc, for callers sharing one handler across routers.
+func CustomWrapHandler(handler http.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		handler(c.Response(), c.Request())
+
+		return nil
+	}
+}
@@ -0,0 +1,39 @@
+package echoSwagger_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	echoSwagger "github.com/oilastudio/http-swagger/echoswagger"
+)
+
+func TestWrapHandler_MountedUnderPrefix(t *testing.T) {
+	e := echo.New()
+	e.GET("/api/v1/swagger/*", echoSwagger.WrapHandler())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/swagger/index.html", nil)
+	w := httptest.NewRecorder()
+
+	e.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+}
+
+func TestWrapHandler_RedirectsRoot(t *testing.T) {
+	e := echo.New()
+	e.GET("/swagger/*", echoSwagger.WrapHandler())
+
+	req := httptest.NewRequest(http.MethodGet, "/swagger/", nil)
+	w := httptest.NewRecorder()
+
+	e.ServeHTTP(w, req)
+
+	if w.Code != http.StatusMovedPermanently {
+		t.Fatalf("expected status 301, got %d", w.Code)
+	}
+}
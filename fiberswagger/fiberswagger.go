@@ -0,0 +1,16 @@
+// Package fiberSwagger wraps httpSwagger.Handler for use with fiber routes
+// such as app.Get("/swagger/*", fiberSwagger.WrapHandler()).
+package fiberSwagger
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+
+	httpSwagger "github.com/oilastudio/http-swagger"
+)
+
+// WrapHandler wraps httpSwagger.Handler and returns a fiber.Handler, using
+// fiber's adaptor middleware since fiber runs on fasthttp rather than net/http.
+func WrapHandler(configFns ...func(*httpSwagger.Config)) fiber.Handler {
+	return adaptor.HTTPHandlerFunc(httpSwagger.Handler(configFns...))
+}
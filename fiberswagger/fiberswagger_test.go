@@ -0,0 +1,43 @@
+package fiberSwagger_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	fiberSwagger "github.com/oilastudio/http-swagger/fiberswagger"
+)
+
+func TestWrapHandler_MountedUnderPrefix(t *testing.T) {
+	app := fiber.New()
+	app.Get("/api/v1/swagger/*", fiberSwagger.WrapHandler())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/swagger/index.html", nil)
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+}
+
+func TestWrapHandler_RedirectsRoot(t *testing.T) {
+	app := fiber.New()
+	app.Get("/swagger/*", fiberSwagger.WrapHandler())
+
+	req := httptest.NewRequest(http.MethodGet, "/swagger/", nil)
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusMovedPermanently {
+		t.Fatalf("expected status 301, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,27 @@
+// Package ginSwagger wraps httpSwagger.Handler for use with gin routes such
+// as router.GET("/swagger/*any", ginSwagger.WrapHandler()).
+package ginSwagger
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	httpSwagger "github.com/oilastudio/http-swagger"
+)
+
+// WrapHandler wraps httpSwagger.Handler and returns a gin.HandlerFunc.
+// gin's wildcard routes (e.g. "/swagger/*any") leave r.RequestURI untouched,
+// so the URL-matching regex in httpSwagger.Handler works unmodified; only the
+// handler func signature needs adapting.
+func WrapHandler(configFns ...func(*httpSwagger.Config)) gin.HandlerFunc {
+	return CustomWrapHandler(httpSwagger.Handler(configFns...))
+}
+
+// CustomWrapHandler adapts an already-built httpSwagger.Handler into a
+// gin.HandlerFunc, for callers sharing one handler across routers.
+func CustomWrapHandler(handler http.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		handler(c.Writer, c.Request)
+	}
+}
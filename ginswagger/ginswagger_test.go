@@ -0,0 +1,43 @@
+package ginSwagger_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	ginSwagger "github.com/oilastudio/http-swagger/ginswagger"
+)
+
+func TestWrapHandler_MountedUnderPrefix(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.GET("/api/v1/swagger/*any", ginSwagger.WrapHandler())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/swagger/index.html", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+}
+
+func TestWrapHandler_RedirectsRoot(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.GET("/swagger/*any", ginSwagger.WrapHandler())
+
+	req := httptest.NewRequest(http.MethodGet, "/swagger/", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusMovedPermanently {
+		t.Fatalf("expected status 301, got %d", w.Code)
+	}
+}
@@ -0,0 +1,106 @@
+package httpSwagger
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandler_IndexHTML_NoCSPNonceByDefault(t *testing.T) {
+	h := Handler()
+
+	req := httptest.NewRequest(http.MethodGet, "/swagger/index.html", nil)
+	w := httptest.NewRecorder()
+	h(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+
+	if strings.Contains(w.Body.String(), "nonce=") {
+		t.Fatalf("expected no nonce attribute without CSPNonce configured, got %q", w.Body.String())
+	}
+}
+
+func TestHandler_IndexHTML_CSPNoncePerRequest(t *testing.T) {
+	h := Handler(CSPNonce(func(r *http.Request) string { return "abc123" }))
+
+	req := httptest.NewRequest(http.MethodGet, "/swagger/index.html", nil)
+	w := httptest.NewRecorder()
+	h(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+
+	if !strings.Contains(w.Body.String(), `nonce="abc123"`) {
+		t.Fatalf("expected nonce attribute in response body, got %q", w.Body.String())
+	}
+}
+
+func TestHandler_SwaggerInitializerJS_IsExternalAsset(t *testing.T) {
+	h := Handler()
+
+	req := httptest.NewRequest(http.MethodGet, "/swagger/swagger_initializer.js", nil)
+	w := httptest.NewRecorder()
+	h(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+
+	if ct := w.Header().Get("Content-Type"); ct != "application/javascript" {
+		t.Fatalf("unexpected content type %q", ct)
+	}
+
+	if !strings.Contains(w.Body.String(), "SwaggerUIBundle") {
+		t.Fatalf("expected initializer script body, got %q", w.Body.String())
+	}
+}
+
+// TestHandler_SwaggerInitializerJS_EscapesAsJSNotHTML guards against
+// swaggerInitializerTempl being executed through html/template outside of a
+// <script> tag, which would treat interpolations as HTML text instead of JS
+// strings and HTML-entity-escape characters like & and " instead of
+// JS-escaping them, corrupting the URL the browser actually requests.
+func TestHandler_SwaggerInitializerJS_EscapesAsJSNotHTML(t *testing.T) {
+	h := Handler(
+		URL(`doc.json?x=1&y=2`),
+		OAuth2RedirectURL(`/oauth2-redirect.html`),
+		OAuth2AppName(`Ella's "API"`),
+	)
+
+	req := httptest.NewRequest(http.MethodGet, "/swagger/swagger_initializer.js", nil)
+	w := httptest.NewRecorder()
+	h(w, req)
+
+	body := w.Body.String()
+	if strings.Contains(body, "&amp;") {
+		t.Fatalf("expected & to be JS-escaped, not HTML-entity-escaped, got %q", body)
+	}
+
+	if !strings.Contains(body, `url: "doc.json?x=1&y=2"`) {
+		t.Fatalf("expected the URL to round-trip unescaped, got %q", body)
+	}
+
+	if !strings.Contains(body, `appName: "Ella's \"API\""`) {
+		t.Fatalf("expected the OAuth2 app name to round-trip JS-escaped, got %q", body)
+	}
+}
+
+func TestHandler_SwaggerIndexCSS_IsExternalAsset(t *testing.T) {
+	h := Handler()
+
+	req := httptest.NewRequest(http.MethodGet, "/swagger/swagger_index.css", nil)
+	w := httptest.NewRecorder()
+	h(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+
+	if ct := w.Header().Get("Content-Type"); ct != "text/css; charset=utf-8" {
+		t.Fatalf("unexpected content type %q", ct)
+	}
+}
@@ -0,0 +1,112 @@
+package httpSwagger
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestResolveSpecRewrite_NoSignal_NotOK(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/swagger/doc.json", nil)
+
+	rw := resolveSpecRewrite(&Config{}, r)
+	if rw.ok {
+		t.Fatalf("expected no rewrite signal, got %+v", rw)
+	}
+}
+
+// TestHandler_DocJSON_MountPrefixDoesNotClobberBasePath guards against
+// regressing to rewriting basePath off the UI's own mount prefix: a UI
+// mounted at /swagger/* commonly fronts an API rooted elsewhere (e.g.
+// /api/v1), so the two must not be conflated absent an explicit signal.
+func TestHandler_DocJSON_MountPrefixDoesNotClobberBasePath(t *testing.T) {
+	h := Handler(WithSpecBytes([]byte(`{"swagger":"2.0","host":"upstream.internal","basePath":"/api/v1"}`)))
+
+	req := httptest.NewRequest(http.MethodGet, "/swagger/doc.json", nil)
+	w := httptest.NewRecorder()
+	h(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+
+	body := w.Body.String()
+	if !strings.Contains(body, `"basePath":"/api/v1"`) {
+		t.Fatalf("expected basePath left untouched, got %q", body)
+	}
+
+	if !strings.Contains(body, `"host":"upstream.internal"`) {
+		t.Fatalf("expected host left untouched, got %q", body)
+	}
+}
+
+func TestHandler_DocJSON_ForwardedPrefixRewritesBasePathAndHost(t *testing.T) {
+	h := Handler(WithSpecBytes([]byte(`{"swagger":"2.0","host":"upstream.internal","basePath":"/api/v1"}`)))
+
+	req := httptest.NewRequest(http.MethodGet, "/swagger/doc.json", nil)
+	req.Header.Set("X-Forwarded-Prefix", "/gateway")
+	req.Host = "gateway.example.com"
+
+	w := httptest.NewRecorder()
+	h(w, req)
+
+	body := w.Body.String()
+	if !strings.Contains(body, `"basePath":"/gateway"`) {
+		t.Fatalf("expected basePath rewritten from X-Forwarded-Prefix, got %q", body)
+	}
+
+	if !strings.Contains(body, `"host":"gateway.example.com"`) {
+		t.Fatalf("expected host rewritten to the request host, got %q", body)
+	}
+}
+
+func TestHandler_DocJSON_SpecBasePathResolverRewritesServers(t *testing.T) {
+	h := Handler(
+		WithSpecBytes([]byte(`{"openapi":"3.0.0","servers":[{"url":"https://upstream.internal/api/v1"}]}`)),
+		SpecBasePathResolver(func(r *http.Request) string { return "/resolved" }),
+	)
+
+	req := httptest.NewRequest(http.MethodGet, "/swagger/doc.json", nil)
+	req.Host = "public.example.com"
+
+	w := httptest.NewRecorder()
+	h(w, req)
+
+	body := w.Body.String()
+	if !strings.Contains(body, `"url":"http://public.example.com/resolved"`) {
+		t.Fatalf("expected servers[].url rewritten via SpecBasePathResolver, got %q", body)
+	}
+}
+
+func TestHandler_DocNamedJSON(t *testing.T) {
+	h := Handler(URLs(map[string]string{"v1": "doc-v1.json"}), WithSpecBytes([]byte(`{"swagger":"2.0"}`)))
+
+	req := httptest.NewRequest(http.MethodGet, "/swagger/doc-v1.json", nil)
+	w := httptest.NewRecorder()
+	h(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+}
+
+func TestHandler_URLResolverOverridesURLs(t *testing.T) {
+	h := Handler(
+		URLs(map[string]string{"v1": "doc-v1.json"}),
+		URLResolver(func(r *http.Request) string { return "/resolved.json" }),
+	)
+
+	req := httptest.NewRequest(http.MethodGet, "/swagger/swagger_initializer.js", nil)
+	w := httptest.NewRecorder()
+	h(w, req)
+
+	body := w.Body.String()
+	if !strings.Contains(body, `url: "/resolved.json"`) {
+		t.Fatalf("expected the resolved URL in the initializer script, got %q", body)
+	}
+
+	if strings.Contains(body, "urls:") {
+		t.Fatalf("expected the urls[] array suppressed once URLResolver is set, got %q", body)
+	}
+}
@@ -0,0 +1,74 @@
+package httpSwagger
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+)
+
+func TestHandler_DocJSON_WithSpecBytes(t *testing.T) {
+	h := Handler(WithSpecBytes([]byte(`{"swagger":"2.0","info":{"title":"t"}}`)))
+
+	req := httptest.NewRequest(http.MethodGet, "/swagger/doc.json", nil)
+	w := httptest.NewRecorder()
+	h(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+
+	if ct := w.Header().Get("Content-Type"); ct != "application/json; charset=utf-8" {
+		t.Fatalf("unexpected content type %q", ct)
+	}
+}
+
+func TestHandler_DocJSON_WithSpecFile(t *testing.T) {
+	fsys := fstest.MapFS{
+		"openapi.json": &fstest.MapFile{Data: []byte(`{"swagger":"2.0"}`)},
+	}
+
+	h := Handler(WithSpecFile(fsys, "openapi.json"))
+
+	req := httptest.NewRequest(http.MethodGet, "/swagger/doc.json", nil)
+	w := httptest.NewRecorder()
+	h(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+}
+
+type erroringSpecProvider struct{}
+
+func (erroringSpecProvider) ReadDoc(_ string) (string, error) {
+	return "", errors.New("boom")
+}
+
+func TestHandler_DocJSON_WithSpecProviderError(t *testing.T) {
+	h := Handler(WithSpecProvider(erroringSpecProvider{}))
+
+	req := httptest.NewRequest(http.MethodGet, "/swagger/doc.json", nil)
+	w := httptest.NewRecorder()
+	h(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d", w.Code)
+	}
+}
+
+func TestHandler_DocNamedJSON_UsesSpecProviderPerName(t *testing.T) {
+	h := Handler(
+		URLs(map[string]string{"v1": "doc-v1.json"}),
+		WithSpecProvider(erroringSpecProvider{}),
+	)
+
+	req := httptest.NewRequest(http.MethodGet, "/swagger/doc-v1.json", nil)
+	w := httptest.NewRecorder()
+	h(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500 from the provider error, got %d", w.Code)
+	}
+}
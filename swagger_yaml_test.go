@@ -0,0 +1,96 @@
+package httpSwagger
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandler_DocYAML_DisabledByDefault(t *testing.T) {
+	h := Handler(WithSpecBytes([]byte(`{"swagger":"2.0"}`)))
+
+	req := httptest.NewRequest(http.MethodGet, "/swagger/doc.yaml", nil)
+	w := httptest.NewRecorder()
+	h(w, req)
+
+	if w.Code == http.StatusOK {
+		t.Fatalf("expected doc.yaml to be disabled under the default SpecFormat, got 200")
+	}
+}
+
+func TestHandler_DocYAML_Both(t *testing.T) {
+	h := Handler(WithSpecBytes([]byte(`{"swagger":"2.0","host":"example.com"}`)), SpecFormat("both"))
+
+	req := httptest.NewRequest(http.MethodGet, "/swagger/doc.yaml", nil)
+	w := httptest.NewRecorder()
+	h(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+
+	if ct := w.Header().Get("Content-Type"); ct != "application/x-yaml; charset=utf-8" {
+		t.Fatalf("unexpected content type %q", ct)
+	}
+
+	if !strings.Contains(w.Body.String(), "host: example.com") {
+		t.Fatalf("expected YAML body, got %q", w.Body.String())
+	}
+}
+
+func TestHandler_DocYAML_JSONOnlyDisablesYAML(t *testing.T) {
+	h := Handler(WithSpecBytes([]byte(`{"swagger":"2.0"}`)), SpecFormat("json"))
+
+	req := httptest.NewRequest(http.MethodGet, "/swagger/doc.yaml", nil)
+	w := httptest.NewRecorder()
+	h(w, req)
+
+	if w.Code == http.StatusOK {
+		t.Fatalf("expected doc.yaml to be disabled under SpecFormat(\"json\"), got 200")
+	}
+}
+
+// TestHandler_DocNamedYAML_URLsWithYAMLFormat guards against the topbar
+// dropdown from URLs() silently 404ing every named spec when SpecFormat is
+// "yaml": doc-<name>.json has a doc-<name>.yaml counterpart mirroring the
+// doc.json/doc.yaml pairing.
+func TestHandler_DocNamedYAML_URLsWithYAMLFormat(t *testing.T) {
+	h := Handler(
+		URLs(map[string]string{"v1": "doc-v1.yaml"}),
+		WithSpecBytes([]byte(`{"swagger":"2.0","host":"example.com"}`)),
+		SpecFormat("yaml"),
+	)
+
+	req := httptest.NewRequest(http.MethodGet, "/swagger/doc-v1.yaml", nil)
+	w := httptest.NewRecorder()
+	h(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+
+	if ct := w.Header().Get("Content-Type"); ct != "application/x-yaml; charset=utf-8" {
+		t.Fatalf("unexpected content type %q", ct)
+	}
+
+	if !strings.Contains(w.Body.String(), "host: example.com") {
+		t.Fatalf("expected YAML body, got %q", w.Body.String())
+	}
+}
+
+func TestHandler_DocNamedYAML_DisabledUnderJSONFormat(t *testing.T) {
+	h := Handler(
+		URLs(map[string]string{"v1": "doc-v1.yaml"}),
+		WithSpecBytes([]byte(`{"swagger":"2.0"}`)),
+		SpecFormat("json"),
+	)
+
+	req := httptest.NewRequest(http.MethodGet, "/swagger/doc-v1.yaml", nil)
+	w := httptest.NewRecorder()
+	h(w, req)
+
+	if w.Code == http.StatusOK {
+		t.Fatalf("expected doc-v1.yaml to be disabled under SpecFormat(\"json\"), got 200")
+	}
+}